@@ -0,0 +1,112 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotify
+
+import (
+	"errors"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// Endpoint is Spotify's OAuth2 endpoint, for use with the
+// golang.org/x/oauth2 package.
+var Endpoint = oauth2.Endpoint{
+	AuthURL:  "https://accounts.spotify.com/authorize",
+	TokenURL: "https://accounts.spotify.com/api/token",
+}
+
+// Authorization scopes understood by the Spotify API.  These are
+// passed to NewAuthenticator and control which user-scoped endpoints
+// the resulting token is able to call.
+const (
+	// ScopeUserReadPrivate lets the application get a user's email
+	// address and date of birth, among other private info.
+	ScopeUserReadPrivate = "user-read-private"
+	// ScopeUserLibraryRead lets the application read a user's
+	// collection of saved tracks and albums.
+	ScopeUserLibraryRead = "user-library-read"
+	// ScopeUserLibraryModify lets the application manage a user's
+	// collection of saved tracks and albums.
+	ScopeUserLibraryModify = "user-library-modify"
+	// ScopePlaylistReadPrivate lets the application access a user's
+	// private playlists.
+	ScopePlaylistReadPrivate = "playlist-read-private"
+	// ScopePlaylistModifyPublic lets the application manage a
+	// user's public playlists.
+	ScopePlaylistModifyPublic = "playlist-modify-public"
+	// ScopePlaylistModifyPrivate lets the application manage a
+	// user's private playlists.
+	ScopePlaylistModifyPrivate = "playlist-modify-private"
+)
+
+// Authenticator handles Spotify's Authorization Code flow.
+// An Authenticator is created with NewAuthenticator and is
+// then used to drive a user through the OAuth2 dance and
+// obtain a *Client that is authorized to call user-scoped
+// endpoints on their behalf.
+type Authenticator struct {
+	config *oauth2.Config
+}
+
+// NewAuthenticator creates an Authenticator that sends the user
+// to the given redirect URL after completing the OAuth2 flow,
+// requesting the given scopes.
+func NewAuthenticator(redirectURL string, scopes ...string) Authenticator {
+	return Authenticator{
+		config: &oauth2.Config{
+			RedirectURL: redirectURL,
+			Scopes:      scopes,
+			Endpoint:    Endpoint,
+		},
+	}
+}
+
+// AuthURL returns a URL that the user should visit in order to
+// grant authorization to the application.  The state parameter
+// is an opaque value used to prevent CSRF attacks; it should be
+// unique per request and verified when the redirect is handled.
+func (a Authenticator) AuthURL(state string) string {
+	return a.config.AuthCodeURL(state)
+}
+
+// Token pulls an authorization code from an HTTP request and
+// exchanges it for an access token.  The state parameter must
+// match the one used to generate the AuthURL, or an error is
+// returned.
+func (a Authenticator) Token(state string, r *http.Request) (*oauth2.Token, error) {
+	values := r.URL.Query()
+	if e := values.Get("error"); e != "" {
+		return nil, errors.New("spotify: auth failed - " + e)
+	}
+	code := values.Get("code")
+	if code == "" {
+		return nil, errors.New("spotify: didn't get access code")
+	}
+	actualState := values.Get("state")
+	if actualState != state {
+		return nil, errors.New("spotify: redirect state parameter doesn't match")
+	}
+	return a.config.Exchange(oauth2.NoContext, code)
+}
+
+// NewClient creates a Client that will use the specified access
+// token for its API requests, automatically refreshing it as
+// needed.
+func (a Authenticator) NewClient(token *oauth2.Token) *Client {
+	return &Client{
+		http: a.config.Client(oauth2.NoContext, token),
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotify
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FindTracksAll resolves an arbitrary number of track IDs by
+// chunking them into batches of up to 50 (the limit enforced by
+// FindTracks) and fetching up to c.Concurrency batches at once.
+// The returned slice preserves the order of ids; a track that
+// Spotify doesn't recognize is nil at its corresponding
+// position, matching FindTracks' per-call contract.  ctx is
+// checked before each batch starts: once it is canceled, the
+// remaining batches are skipped and ctx's error is returned.  A
+// FindTracks call already in progress is not interrupted, so a
+// cancellation after every batch has started has no effect.
+func (c *Client) FindTracksAll(ctx context.Context, ids []ID) ([]*FullTrack, error) {
+	concurrency := c.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	chunks := chunkIDs(ids, 50)
+	results := make([][]*FullTrack, len(chunks))
+	errs := make([]error, len(chunks))
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, chunk []ID) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				errs[i] = ctx.Err()
+				return
+			}
+			results[i], errs[i] = c.FindTracks(chunk...)
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	out := make([]*FullTrack, 0, len(ids))
+	for _, r := range results {
+		out = append(out, r...)
+	}
+	return out, nil
+}
+
+// chunkIDs splits ids into consecutive slices of at most size
+// elements each.
+func chunkIDs(ids []ID, size int) [][]ID {
+	var chunks [][]ID
+	for size < len(ids) {
+		ids, chunks = ids[size:], append(chunks, ids[:size:size])
+	}
+	return append(chunks, ids)
+}
+
+// TrackLoader coalesces concurrent FindTrack calls issued within
+// a short window into a single FindTracks batch, dataloader
+// style, to cut down on request volume when many callers are
+// resolving tracks at once (e.g. while enriching a large
+// library).  The zero value is not usable; create one with
+// NewTrackLoader.
+type TrackLoader struct {
+	client   *Client
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	batch *trackBatch
+}
+
+type trackBatch struct {
+	ids     []ID
+	waiters []chan trackResult
+	flushed bool
+}
+
+type trackResult struct {
+	track *FullTrack
+	err   error
+}
+
+// NewTrackLoader creates a TrackLoader that batches calls to
+// Load made within a 5ms window, up to 50 IDs per batch.
+func NewTrackLoader(c *Client) *TrackLoader {
+	return &TrackLoader{client: c, wait: 5 * time.Millisecond, maxBatch: 50}
+}
+
+// Load resolves id, transparently joining it to an in-flight
+// batch of other Load calls when possible.
+func (l *TrackLoader) Load(id ID) (*FullTrack, error) {
+	ch := make(chan trackResult, 1)
+
+	l.mu.Lock()
+	if l.batch == nil {
+		b := &trackBatch{}
+		l.batch = b
+		time.AfterFunc(l.wait, func() { l.flush(b) })
+	}
+	l.batch.ids = append(l.batch.ids, id)
+	l.batch.waiters = append(l.batch.waiters, ch)
+	if len(l.batch.ids) >= l.maxBatch {
+		b := l.batch
+		l.batch = nil
+		go l.flush(b)
+	}
+	l.mu.Unlock()
+
+	r := <-ch
+	return r.track, r.err
+}
+
+func (l *TrackLoader) flush(b *trackBatch) {
+	l.mu.Lock()
+	if l.batch == b {
+		l.batch = nil
+	}
+	if b.flushed {
+		l.mu.Unlock()
+		return
+	}
+	b.flushed = true
+	l.mu.Unlock()
+
+	tracks, err := l.client.FindTracks(b.ids...)
+	for i, ch := range b.waiters {
+		switch {
+		case err != nil:
+			ch <- trackResult{err: err}
+		case i < len(tracks):
+			ch <- trackResult{track: tracks[i]}
+		default:
+			// Spotify returned fewer tracks than requested;
+			// treat the missing positions as misses rather
+			// than indexing out of range.
+			ch <- trackResult{}
+		}
+		close(ch)
+	}
+}
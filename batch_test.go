@@ -0,0 +1,132 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestChunkIDs(t *testing.T) {
+	ids := []ID{"a", "b", "c", "d", "e"}
+	got := chunkIDs(ids, 2)
+	want := [][]ID{{"a", "b"}, {"c", "d"}, {"e"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("chunkIDs() = %v, want %v", got, want)
+	}
+}
+
+func TestFindTracksAllPreservesOrder(t *testing.T) {
+	json := `{ "tracks": [ { "name": "Idioteque" }, { "name": "Everything In Its Right Place" } ] }`
+	client := testClientString(http.StatusOK, json)
+	addDummyAuth(client)
+	client.Concurrency = 4
+
+	ids := make([]ID, 120) // 3 chunks of 50/50/20
+	for i := range ids {
+		ids[i] = ID("id")
+	}
+	tracks, err := client.FindTracksAll(context.Background(), ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tracks) != 2*3 {
+		t.Fatalf("got %d tracks, want %d", len(tracks), 2*3)
+	}
+	for i, want := range []string{"Idioteque", "Everything In Its Right Place"} {
+		if tracks[i].Name != want {
+			t.Errorf("tracks[%d].Name = %q, want %q", i, tracks[i].Name, want)
+		}
+	}
+}
+
+func TestFindTracksAllCanceled(t *testing.T) {
+	client := testClientString(http.StatusOK, `{"tracks":[]}`)
+	addDummyAuth(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.FindTracksAll(ctx, []ID{"a"}); err == nil {
+		t.Error("expected an error from an already-canceled context")
+	}
+}
+
+func TestTrackLoaderCoalescesCalls(t *testing.T) {
+	var calls int32
+	json := `{ "tracks": [ { "name": "one" }, { "name": "two" }, { "name": "three" } ] }`
+	client := testClientString(http.StatusOK, json)
+	addDummyAuth(client)
+	client.http.Transport = countingRoundTripper{next: client.http.Transport, calls: &calls}
+
+	loader := NewTrackLoader(client)
+	type result struct {
+		track *FullTrack
+		err   error
+	}
+	results := make(chan result, 3)
+	for _, id := range []ID{"a", "b", "c"} {
+		go func(id ID) {
+			track, err := loader.Load(id)
+			results <- result{track, err}
+		}(id)
+	}
+	for i := 0; i < 3; i++ {
+		r := <-results
+		if r.err != nil {
+			t.Fatal(r.err)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("got %d underlying HTTP calls, want 1 (batched)", got)
+	}
+}
+
+func TestTrackLoaderFlushHandlesShortResponse(t *testing.T) {
+	client := testClientString(http.StatusOK, `{"tracks":[{"name":"only one"}]}`)
+	addDummyAuth(client)
+	loader := NewTrackLoader(client)
+
+	b := &trackBatch{ids: []ID{"a", "b"}}
+	ch0 := make(chan trackResult, 1)
+	ch1 := make(chan trackResult, 1)
+	b.waiters = []chan trackResult{ch0, ch1}
+
+	loader.flush(b)
+
+	r0 := <-ch0
+	if r0.err != nil || r0.track == nil || r0.track.Name != "only one" {
+		t.Errorf("unexpected result for waiter 0: %+v", r0)
+	}
+	r1 := <-ch1
+	if r1.err != nil || r1.track != nil {
+		t.Errorf("expected a nil-track miss for waiter 1, got %+v", r1)
+	}
+}
+
+// countingRoundTripper counts the number of requests that reach
+// the underlying transport.
+type countingRoundTripper struct {
+	next  http.RoundTripper
+	calls *int32
+}
+
+func (c countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(c.calls, 1)
+	return c.next.RoundTrip(req)
+}
@@ -0,0 +1,31 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotify
+
+import "net/http"
+
+// Client is used to invoke Spotify's Web API.  Every method on
+// Client that hits the network does so through the http field,
+// so installing a custom *http.Client (e.g. one produced by
+// Authenticator.NewClient or wrapped with UseRateLimiter) is
+// enough to change how every request is made.
+type Client struct {
+	http *http.Client
+
+	// Concurrency bounds how many requests FindTracksAll is
+	// allowed to have in flight at once.  A value <= 0 is
+	// treated as 1 (sequential).
+	Concurrency int
+}
@@ -0,0 +1,64 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nowplaying reads the track currently loaded in the
+// local, desktop Spotify client.  Unlike the main spotify
+// package, it requires no OAuth2 user authorization: it talks
+// to the Spotify application (or its OS-level media session)
+// running on the same machine.
+package nowplaying
+
+import "time"
+
+// NowPlaying describes the track currently loaded in the local
+// Spotify client.
+type NowPlaying struct {
+	// TrackID is the Spotify ID of the track, suitable for
+	// passing to spotify.Client.FindTrack.
+	TrackID string
+	// Name is the track's title.
+	Name string
+	// Artist is the track's artist.
+	Artist string
+	// Album is the album the track appears on.
+	Album string
+	// DiscNumber is the disc the track appears on.
+	DiscNumber int
+	// TrackNumber is the track's position on its disc.
+	TrackNumber int
+	// Duration is the length of the track.
+	Duration time.Duration
+	// Position is how far into the track playback has
+	// progressed.
+	Position time.Duration
+	// PlayedCount is the number of times the track has been
+	// played, if the provider exposes it.
+	PlayedCount int
+	// Popularity is the track's Spotify popularity, if the
+	// provider exposes it.
+	Popularity int
+	// IsPlaying is true if the client is currently playing
+	// the track, false if it is paused or stopped.
+	IsPlaying bool
+}
+
+// Provider reads the currently playing track from a local
+// Spotify client.  Implementations are platform-specific; use
+// New to obtain the one appropriate for the running OS.
+type Provider interface {
+	// NowPlaying returns the track currently loaded in the
+	// local Spotify client.  It returns an error if Spotify
+	// isn't running or its state can't be read.
+	NowPlaying() (*NowPlaying, error)
+}
@@ -0,0 +1,84 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nowplaying
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fields are read from the Spotify AppleScript dictionary in a
+// single osascript call, separated by "||" so they can be split
+// back out reliably.
+const script = `
+tell application "Spotify"
+	set t to current track
+	return (artist of t) & "||" & (album of t) & "||" & (name of t) & "||" & ¬
+		(disc number of t) & "||" & (duration of t) & "||" & (played count of t) & "||" & ¬
+		(track number of t) & "||" & (popularity of t) & "||" & (id of t) & "||" & ¬
+		(player state as string) & "||" & (player position as string)
+end tell
+`
+
+// darwinProvider reads Spotify's state via osascript, querying
+// the AppleScript dictionary that the desktop client exposes.
+type darwinProvider struct{}
+
+// New returns a Provider that reads the desktop Spotify
+// client's state via AppleScript.
+func New() Provider {
+	return darwinProvider{}
+}
+
+func (darwinProvider) NowPlaying() (*NowPlaying, error) {
+	out, err := exec.Command("osascript", "-e", script).Output()
+	if err != nil {
+		return nil, fmt.Errorf("nowplaying: osascript failed: %w", err)
+	}
+	fields := strings.Split(strings.TrimSpace(string(out)), "||")
+	if len(fields) != 11 {
+		return nil, fmt.Errorf("nowplaying: unexpected osascript output: %q", out)
+	}
+	discNumber, _ := strconv.Atoi(fields[3])
+	durationMs, _ := strconv.Atoi(fields[4])
+	playedCount, _ := strconv.Atoi(fields[5])
+	trackNumber, _ := strconv.Atoi(fields[6])
+	popularity, _ := strconv.Atoi(fields[7])
+	positionSecs, _ := strconv.ParseFloat(fields[10], 64)
+
+	// Spotify's AppleScript "id" is reported as
+	// "spotify:track:<id>"; callers want the bare ID.
+	id := fields[8]
+	if i := strings.LastIndex(id, ":"); i != -1 {
+		id = id[i+1:]
+	}
+
+	return &NowPlaying{
+		TrackID:     id,
+		Name:        fields[2],
+		Artist:      fields[0],
+		Album:       fields[1],
+		DiscNumber:  discNumber,
+		TrackNumber: trackNumber,
+		Duration:    time.Duration(durationMs) * time.Millisecond,
+		Position:    time.Duration(positionSecs * float64(time.Second)),
+		PlayedCount: playedCount,
+		Popularity:  popularity,
+		IsPlaying:   fields[9] == "playing",
+	}, nil
+}
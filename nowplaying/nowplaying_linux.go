@@ -0,0 +1,108 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nowplaying
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	mprisDest  = "org.mpris.MediaPlayer2.spotify"
+	mprisPath  = "/org/mpris/MediaPlayer2"
+	mprisIface = "org.mpris.MediaPlayer2.Player"
+)
+
+// linuxProvider reads Spotify's state over the MPRIS D-Bus
+// interface that the desktop client registers as
+// org.mpris.MediaPlayer2.spotify.
+type linuxProvider struct{}
+
+// New returns a Provider that reads the desktop Spotify
+// client's state over MPRIS D-Bus.
+func New() Provider {
+	return linuxProvider{}
+}
+
+func (linuxProvider) NowPlaying() (*NowPlaying, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, err
+	}
+	obj := conn.Object(mprisDest, dbus.ObjectPath(mprisPath))
+
+	props, err := obj.GetProperty(mprisIface + ".Metadata")
+	if err != nil {
+		return nil, errors.New("nowplaying: Spotify doesn't appear to be running")
+	}
+	metadata, ok := props.Value().(map[string]dbus.Variant)
+	if !ok {
+		return nil, errors.New("nowplaying: unexpected MPRIS metadata")
+	}
+
+	status, err := obj.GetProperty(mprisIface + ".PlaybackStatus")
+	if err != nil {
+		return nil, err
+	}
+	positionUs, err := obj.GetProperty(mprisIface + ".Position")
+	if err != nil {
+		return nil, err
+	}
+
+	np := &NowPlaying{
+		Name:      stringProp(metadata, "xesam:title"),
+		Album:     stringProp(metadata, "xesam:album"),
+		TrackID:   trackID(stringProp(metadata, "mpris:trackid")),
+		Duration:  time.Duration(int64Prop(metadata, "mpris:length")) * time.Microsecond,
+		Position:  time.Duration(positionUs.Value().(int64)) * time.Microsecond,
+		IsPlaying: status.Value().(string) == "Playing",
+	}
+	if artists, ok := metadata["xesam:artist"].Value().([]string); ok && len(artists) > 0 {
+		np.Artist = artists[0]
+	}
+	return np, nil
+}
+
+func stringProp(m map[string]dbus.Variant, key string) string {
+	if v, ok := m[key]; ok {
+		if s, ok := v.Value().(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+func int64Prop(m map[string]dbus.Variant, key string) int64 {
+	if v, ok := m[key]; ok {
+		if n, ok := v.Value().(int64); ok {
+			return n
+		}
+	}
+	return 0
+}
+
+// trackID extracts the bare Spotify ID from an MPRIS track ID,
+// which Spotify reports either as an object path
+// ("/com/spotify/track/<id>") or, just as often, as a URI
+// ("spotify:track:<id>").
+func trackID(id string) string {
+	if i := strings.LastIndexAny(id, "/:"); i != -1 {
+		return id[i+1:]
+	}
+	return id
+}
@@ -0,0 +1,44 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nowplaying
+
+import "errors"
+
+// windowsProvider is a deliberate stub, not an implementation.
+// The Windows half of this package's request (SMTC session
+// access, with a Web Playback SDK WebSocket fallback) is not
+// done: SMTC requires WinRT bindings that aren't available to
+// plain Go, and wiring up a WebSocket fallback depends on a
+// running Web Playback SDK session this package has no way to
+// drive yet.  Until one of those lands, NowPlaying reports an
+// error rather than silently returning stale or fabricated
+// data.  This is flagged here as outstanding work, not shipped
+// as done — darwin and linux are real implementations, windows
+// is not.
+//
+// TODO: implement via the WinRT GlobalSystemMediaTransportControlsSessionManager
+// API, falling back to a WebSocket connection to Spotify's Web
+// Playback SDK when no local session is registered.
+type windowsProvider struct{}
+
+// New returns a Provider for the desktop Spotify client on
+// Windows.
+func New() Provider {
+	return windowsProvider{}
+}
+
+func (windowsProvider) NowPlaying() (*NowPlaying, error) {
+	return nil, errors.New("nowplaying: windows SMTC support is not yet implemented")
+}
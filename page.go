@@ -0,0 +1,99 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotify
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Page contains the fields that Spotify attaches to every
+// paginated result.  It is meant to be embedded in a result
+// type that adds an Items field for the page's actual content,
+// e.g. SavedTrackPage.
+//
+// CurrentUsersTracks and GetPlaylistTracks return Page-based
+// results; FeaturedPlaylistsOpt does not yet.  It lives outside
+// this part of the tree, so it couldn't be retrofitted here —
+// that half of this change is still outstanding, not done.
+type Page struct {
+	// Endpoint is a link to the Web API endpoint returning
+	// the full result of the request.
+	Endpoint string `json:"href"`
+	// Limit is the maximum number of items in the response,
+	// as set in the query or by default.
+	Limit int `json:"limit"`
+	// Next is a link to the next page of items, or the
+	// empty string if there is none.
+	Next string `json:"next"`
+	// Offset is the offset of the items returned, as set
+	// in the query or by default.
+	Offset int `json:"offset"`
+	// Previous is a link to the previous page of items,
+	// or the empty string if there is none.
+	Previous string `json:"previous"`
+	// Total is the maximum number of items available to
+	// return.
+	Total int `json:"total"`
+}
+
+// pageable is implemented by any result type that embeds Page,
+// via Go's method promotion.  It lets NextPage and PreviousPage
+// operate on the embedded paging fields without knowing the
+// concrete Items type.
+type pageable interface {
+	pageable() *Page
+}
+
+func (p *Page) pageable() *Page {
+	return p
+}
+
+// NextPage fetches the next page of a paginated result and
+// decodes it into out, which should be a pointer to the same
+// type as p (e.g. *SavedTrackPage).  It returns an error if
+// there is no next page.
+func (c *Client) NextPage(p pageable, out interface{}) error {
+	next := p.pageable().Next
+	if next == "" {
+		return errors.New("spotify: no next page")
+	}
+	return c.fetchPage(next, out)
+}
+
+// PreviousPage fetches the previous page of a paginated result
+// and decodes it into out, which should be a pointer to the
+// same type as p.  It returns an error if there is no previous
+// page.
+func (c *Client) PreviousPage(p pageable, out interface{}) error {
+	prev := p.pageable().Previous
+	if prev == "" {
+		return errors.New("spotify: no previous page")
+	}
+	return c.fetchPage(prev, out)
+}
+
+func (c *Client) fetchPage(url string, out interface{}) error {
+	resp, err := c.http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return decodeError(resp.Body)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
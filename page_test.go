@@ -0,0 +1,85 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestNextPageNoNext(t *testing.T) {
+	var client Client
+	page := &SavedTrackPage{}
+	var out SavedTrackPage
+	if err := client.NextPage(page, &out); err == nil {
+		t.Error("expected an error when there is no next page")
+	}
+}
+
+func TestPreviousPageNoPrevious(t *testing.T) {
+	var client Client
+	page := &SavedTrackPage{}
+	var out SavedTrackPage
+	if err := client.PreviousPage(page, &out); err == nil {
+		t.Error("expected an error when there is no previous page")
+	}
+}
+
+func TestNextPage(t *testing.T) {
+	json := `{
+		"href": "https://api.spotify.com/v1/me/tracks?offset=1&limit=1",
+		"items": [ { "track": { "name": "Paranoid Android" } } ],
+		"limit": 1,
+		"next": "",
+		"offset": 1,
+		"previous": "https://api.spotify.com/v1/me/tracks?offset=0&limit=1",
+		"total": 2
+	}`
+	client := testClientString(http.StatusOK, json)
+	addDummyAuth(client)
+
+	first := &SavedTrackPage{Page: Page{Next: "https://api.spotify.com/v1/me/tracks?offset=1&limit=1"}}
+	var second SavedTrackPage
+	if err := client.NextPage(first, &second); err != nil {
+		t.Fatal(err)
+	}
+	if len(second.Tracks) != 1 || second.Tracks[0].Name != "Paranoid Android" {
+		t.Errorf("unexpected tracks: %+v", second.Tracks)
+	}
+}
+
+func TestAllSavedTracksError(t *testing.T) {
+	json := `{
+		"error": {
+			"status": 401,
+			"message": "The access token expired"
+		}
+	}`
+	client := testClientString(http.StatusUnauthorized, json)
+	addDummyAuth(client)
+
+	it := client.AllSavedTracks(context.Background())
+	count := 0
+	for range it.Tracks() {
+		count++
+	}
+	if count != 0 {
+		t.Errorf("expected no tracks, got %d", count)
+	}
+	if it.Err() == nil {
+		t.Error("expected Err to report the failed request")
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotify
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter throttles the requests made by a Client so that
+// bulk usage doesn't trip Spotify's rate limits.  When AutoRetry
+// is enabled, it also transparently retries requests that come
+// back with HTTP 429, honoring the Retry-After header.
+type RateLimiter struct {
+	// AutoRetry enables automatic retries of rate-limited
+	// (HTTP 429) requests.  Defaults to false.
+	AutoRetry bool
+	// MaxRetries bounds how many times a single request is
+	// retried before giving up.  Defaults to 3 if unset.
+	MaxRetries int
+
+	limiter *rate.Limiter
+}
+
+// NewRateLimiter creates a RateLimiter that allows up to rps
+// requests per second, with bursts of up to burst requests.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		MaxRetries: 3,
+		limiter:    rate.NewLimiter(rate.Limit(rps), burst),
+	}
+}
+
+// DefaultRateLimiter returns a RateLimiter with sensible
+// defaults: 10 requests per second, with bursts of up to 10.
+func DefaultRateLimiter() *RateLimiter {
+	return NewRateLimiter(10, 10)
+}
+
+// UseRateLimiter installs rl on the client, wrapping its
+// existing transport so that every request it makes is
+// throttled and, if rl.AutoRetry is set, automatically retried
+// on HTTP 429 responses.
+func (c *Client) UseRateLimiter(rl *RateLimiter) {
+	c.http.Transport = &rateLimitedTransport{rl: rl, next: c.http.Transport}
+}
+
+// rateLimitedTransport is an http.RoundTripper that enforces a
+// RateLimiter before delegating to the next transport in the
+// chain.
+type rateLimitedTransport struct {
+	rl   *RateLimiter
+	next http.RoundTripper
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retries := 0
+	for {
+		if err := t.rl.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		resp, err := t.base().RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || !t.rl.AutoRetry {
+			return resp, err
+		}
+		maxRetries := t.rl.MaxRetries
+		if maxRetries == 0 {
+			maxRetries = 3
+		}
+		if retries >= maxRetries {
+			return resp, err
+		}
+		// The request body (if any) was already consumed by the
+		// RoundTrip above; rewind it before replaying the
+		// request, or give up if it can't be rewound, so writes
+		// like AddTracksToPlaylist don't silently retry empty.
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req.Body = body
+		}
+		wait := retryAfter(resp)
+		resp.Body.Close()
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		retries++
+	}
+}
+
+func (t *rateLimitedTransport) base() http.RoundTripper {
+	if t.next != nil {
+		return t.next
+	}
+	return http.DefaultTransport
+}
+
+// retryAfter parses the Retry-After header of a 429 response,
+// defaulting to one second if it is missing or malformed.
+func retryAfter(resp *http.Response) time.Duration {
+	s := resp.Header.Get("Retry-After")
+	if secs, err := strconv.Atoi(s); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	return time.Second
+}
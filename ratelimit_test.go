@@ -0,0 +1,130 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotify
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+// scriptedTransport replays a fixed sequence of status codes,
+// recording the body it saw on each call so tests can assert
+// that retries resend it.
+type scriptedTransport struct {
+	codes []int
+	calls int
+	seen  [][]byte
+}
+
+func (s *scriptedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+	}
+	s.seen = append(s.seen, body)
+
+	code := s.codes[s.calls]
+	if s.calls < len(s.codes)-1 {
+		s.calls++
+	}
+	resp := &http.Response{
+		StatusCode: code,
+		Header:     http.Header{"Retry-After": []string{"0"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+	}
+	return resp, nil
+}
+
+func TestRateLimitedTransportRetries(t *testing.T) {
+	st := &scriptedTransport{codes: []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK}}
+	rt := &rateLimitedTransport{rl: &RateLimiter{AutoRetry: true, MaxRetries: 3, limiter: DefaultRateLimiter().limiter}, next: st}
+
+	req, err := http.NewRequest("GET", "https://api.spotify.com/v1/tracks/xyz", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("got status %d, want 200 after retries", resp.StatusCode)
+	}
+	if st.calls != 2 {
+		t.Errorf("got %d retries, want 2", st.calls)
+	}
+}
+
+func TestRateLimitedTransportNoAutoRetry(t *testing.T) {
+	st := &scriptedTransport{codes: []int{http.StatusTooManyRequests}}
+	rt := &rateLimitedTransport{rl: &RateLimiter{AutoRetry: false, limiter: DefaultRateLimiter().limiter}, next: st}
+
+	req, _ := http.NewRequest("GET", "https://api.spotify.com/v1/tracks/xyz", nil)
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("got status %d, want 429 when AutoRetry is disabled", resp.StatusCode)
+	}
+}
+
+func TestRateLimitedTransportRewindsBody(t *testing.T) {
+	st := &scriptedTransport{codes: []int{http.StatusTooManyRequests, http.StatusOK}}
+	rt := &rateLimitedTransport{rl: &RateLimiter{AutoRetry: true, MaxRetries: 3, limiter: DefaultRateLimiter().limiter}, next: st}
+
+	req, err := http.NewRequest("POST", "https://api.spotify.com/v1/me/tracks", bytes.NewReader([]byte(`{"ids":["abc"]}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if len(st.seen) != 2 {
+		t.Fatalf("got %d requests, want 2", len(st.seen))
+	}
+	for i, body := range st.seen {
+		if string(body) != `{"ids":["abc"]}` {
+			t.Errorf("request %d: got body %q, want original body resent", i, body)
+		}
+	}
+}
+
+func TestRateLimitedTransportGivesUpOnUnrewindableBody(t *testing.T) {
+	st := &scriptedTransport{codes: []int{http.StatusTooManyRequests, http.StatusOK}}
+	rt := &rateLimitedTransport{rl: &RateLimiter{AutoRetry: true, MaxRetries: 3, limiter: DefaultRateLimiter().limiter}, next: st}
+
+	req, err := http.NewRequest("POST", "https://api.spotify.com/v1/me/tracks", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Simulate a body with no GetBody, which can't be rewound.
+	req.Body = ioutil.NopCloser(bytes.NewReader([]byte(`{"ids":["abc"]}`)))
+	req.GetBody = nil
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("got status %d, want the original 429 when the body can't be rewound", resp.StatusCode)
+	}
+	if len(st.seen) != 1 {
+		t.Errorf("got %d requests, want 1 (no retry) when the body can't be rewound", len(st.seen))
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SearchType represents the type of a search, and can be
+// bitwise OR'd together to search for multiple types at once.
+type SearchType int
+
+const (
+	SearchTypeAlbum SearchType = 1 << iota
+	SearchTypeArtist
+	SearchTypePlaylist
+	SearchTypeTrack
+)
+
+func (st SearchType) String() string {
+	var types []string
+	if st&SearchTypeAlbum != 0 {
+		types = append(types, "album")
+	}
+	if st&SearchTypeArtist != 0 {
+		types = append(types, "artist")
+	}
+	if st&SearchTypePlaylist != 0 {
+		types = append(types, "playlist")
+	}
+	if st&SearchTypeTrack != 0 {
+		types = append(types, "track")
+	}
+	return strings.Join(types, ",")
+}
+
+// SearchOptions contains optional parameters that can be used
+// to narrow a search.
+type SearchOptions struct {
+	// Market is an ISO 3166-1 alpha-2 country code.  If set,
+	// only content playable in that market is returned.
+	Market *string
+	// Limit is the maximum number of results to return per
+	// search type (1-50).
+	Limit *int
+	// Offset is the index of the first result to return.
+	Offset *int
+}
+
+// TrackPage contains the tracks returned by a search.
+type TrackPage struct {
+	Page
+	Tracks []FullTrack `json:"items"`
+}
+
+// ArtistPage contains the artists returned by a search.
+type ArtistPage struct {
+	Page
+	Artists []FullArtist `json:"items"`
+}
+
+// AlbumPage contains the albums returned by a search.
+type AlbumPage struct {
+	Page
+	Albums []FullAlbum `json:"items"`
+}
+
+// PlaylistPage contains the playlists returned by a search.
+type PlaylistPage struct {
+	Page
+	Playlists []SimplePlaylist `json:"items"`
+}
+
+// SearchResult holds the results of a search.  Only the fields
+// corresponding to the requested SearchType are populated; the
+// rest are left nil.
+type SearchResult struct {
+	Artists   *ArtistPage   `json:"artists,omitempty"`
+	Albums    *AlbumPage    `json:"albums,omitempty"`
+	Playlists *PlaylistPage `json:"playlists,omitempty"`
+	Tracks    *TrackPage    `json:"tracks,omitempty"`
+}
+
+// Search is a wrapper around DefaultClient.Search.
+func Search(query string, t SearchType) (*SearchResult, error) {
+	return DefaultClient.Search(query, t)
+}
+
+// Search queries Spotify's catalog for content matching query,
+// restricted to the given SearchType(s), which may be combined
+// with a bitwise OR (e.g. SearchTypeTrack|SearchTypeArtist).
+func (c *Client) Search(query string, t SearchType) (*SearchResult, error) {
+	return c.SearchOpt(query, t, nil)
+}
+
+// SearchOpt is like Search, but accepts additional options to
+// narrow the results, such as market, limit, and offset.
+func (c *Client) SearchOpt(query string, t SearchType, opt *SearchOptions) (*SearchResult, error) {
+	v := url.Values{}
+	v.Set("q", query)
+	v.Set("type", t.String())
+	if opt != nil {
+		if opt.Market != nil {
+			v.Set("market", *opt.Market)
+		}
+		if opt.Limit != nil {
+			v.Set("limit", strconv.Itoa(*opt.Limit))
+		}
+		if opt.Offset != nil {
+			v.Set("offset", strconv.Itoa(*opt.Offset))
+		}
+	}
+	uri := baseAddress + "search?" + v.Encode()
+	resp, err := c.http.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp.Body)
+	}
+	var result SearchResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
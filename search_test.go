@@ -0,0 +1,80 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotify
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSearchTypeString(t *testing.T) {
+	tests := []struct {
+		t    SearchType
+		want string
+	}{
+		{SearchTypeTrack, "track"},
+		{SearchTypeArtist | SearchTypeAlbum, "album,artist"},
+		{SearchTypeAlbum | SearchTypeArtist | SearchTypePlaylist | SearchTypeTrack, "album,artist,playlist,track"},
+	}
+	for _, tt := range tests {
+		if got := tt.t.String(); got != tt.want {
+			t.Errorf("SearchType(%d).String() = %q, want %q", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestSearchOpt(t *testing.T) {
+	json := `{
+		"tracks": {
+			"href": "https://api.spotify.com/v1/search?query=x&offset=0&limit=1&type=track",
+			"items": [ { "name": "Mr. Brightside" } ],
+			"limit": 1,
+			"offset": 0,
+			"total": 1
+		}
+	}`
+	client := testClientString(http.StatusOK, json)
+	addDummyAuth(client)
+
+	limit := 1
+	result, err := client.SearchOpt("Mr. Brightside", SearchTypeTrack, &SearchOptions{Limit: &limit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Tracks == nil {
+		t.Fatal("expected a populated TrackPage")
+	}
+	if result.Artists != nil || result.Albums != nil || result.Playlists != nil {
+		t.Error("expected only Tracks to be populated")
+	}
+	if len(result.Tracks.Tracks) != 1 || result.Tracks.Tracks[0].Name != "Mr. Brightside" {
+		t.Errorf("unexpected tracks: %+v", result.Tracks.Tracks)
+	}
+}
+
+func TestSearchOptError(t *testing.T) {
+	json := `{
+		"error": {
+			"status": 401,
+			"message": "The access token expired"
+		}
+	}`
+	client := testClientString(http.StatusUnauthorized, json)
+	addDummyAuth(client)
+
+	if _, err := client.Search("query", SearchTypeTrack); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -86,6 +86,10 @@ type PlaylistTrack struct {
 // SavedTrack provides info about a track saved
 // to a user's account.
 type SavedTrack struct {
+	// The date and time the track was saved.
+	AddedAt Timestamp `json:"added_at"`
+	// Information about the track.
+	FullTrack `json:"track"`
 }
 
 // TimeDuration returns the track's duration as a
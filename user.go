@@ -0,0 +1,226 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// SavedTrackPage contains a page of tracks saved to a user's
+// library, along with the paging information needed to fetch
+// adjacent pages via Client.NextPage / Client.PreviousPage.
+type SavedTrackPage struct {
+	Page
+	Tracks []SavedTrack `json:"items"`
+}
+
+// CurrentUser gets detailed profile information about the
+// current user.  It requires the ScopeUserReadPrivate scope
+// if the user's email address and date of birth are needed.
+func (c *Client) CurrentUser() (*User, error) {
+	resp, err := c.http.Get(baseAddress + "me")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp.Body)
+	}
+	var u User
+	if err := json.NewDecoder(resp.Body).Decode(&u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// CurrentUsersTracks gets a page of tracks saved to the current
+// user's library.  Requires the ScopeUserLibraryRead scope.
+// Use Client.NextPage to fetch subsequent pages.
+func (c *Client) CurrentUsersTracks() (*SavedTrackPage, error) {
+	resp, err := c.http.Get(baseAddress + "me/tracks")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp.Body)
+	}
+	var result SavedTrackPage
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SavedTrackIterator yields the tracks delivered by
+// Client.AllSavedTracks.
+type SavedTrackIterator struct {
+	tracks chan SavedTrack
+	err    error
+}
+
+// Tracks returns the channel of saved tracks.  It is closed
+// when iteration ends, whether that's because the collection
+// was exhausted, ctx was canceled, or the API returned an
+// error; once it's closed, call Err to tell those cases apart.
+func (it *SavedTrackIterator) Tracks() <-chan SavedTrack {
+	return it.tracks
+}
+
+// Err returns the error, if any, that caused iteration to stop
+// before the collection was exhausted.  It's only safe to call
+// after the Tracks channel has been drained and closed.
+func (it *SavedTrackIterator) Err() error {
+	return it.err
+}
+
+// AllSavedTracks returns an iterator over every track saved to
+// the current user's library, transparently following "next"
+// links until the entire collection has been delivered or ctx
+// is canceled.
+func (c *Client) AllSavedTracks(ctx context.Context) *SavedTrackIterator {
+	it := &SavedTrackIterator{tracks: make(chan SavedTrack)}
+	go func() {
+		defer close(it.tracks)
+		page, err := c.CurrentUsersTracks()
+		for err == nil {
+			for _, t := range page.Tracks {
+				select {
+				case it.tracks <- t:
+				case <-ctx.Done():
+					it.err = ctx.Err()
+					return
+				}
+			}
+			if page.Next == "" {
+				return
+			}
+			var next SavedTrackPage
+			err = c.NextPage(page, &next)
+			page = &next
+		}
+		it.err = err
+	}()
+	return it
+}
+
+// PlaylistTrackPage contains a page of tracks belonging to a
+// playlist, along with the paging information needed to fetch
+// adjacent pages via Client.NextPage / Client.PreviousPage.
+type PlaylistTrackPage struct {
+	Page
+	Tracks []PlaylistTrack `json:"items"`
+}
+
+// GetPlaylistTracks gets a page of the tracks in the specified
+// user's playlist.
+func (c *Client) GetPlaylistTracks(userID, playlistID string) (*PlaylistTrackPage, error) {
+	uri := baseAddress + "users/" + userID + "/playlists/" + playlistID + "/tracks"
+	resp, err := c.http.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp.Body)
+	}
+	var result PlaylistTrackPage
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AddTracksToLibrary saves one or more tracks to the current
+// user's library.  Requires the ScopeUserLibraryModify scope.
+func (c *Client) AddTracksToLibrary(ids ...ID) error {
+	uri := baseAddress + "me/tracks?ids=" + strings.Join(toStringSlice(ids), ",")
+	req, err := http.NewRequest("PUT", uri, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return decodeError(resp.Body)
+	}
+	return nil
+}
+
+// CreatePlaylistForUser creates a playlist for the given user.
+// The playlist will be private unless public is true.  Creating
+// a playlist requires the ScopePlaylistModifyPublic scope, or
+// ScopePlaylistModifyPrivate if public is false.
+func (c *Client) CreatePlaylistForUser(userID, playlistName string, public bool) (*Playlist, error) {
+	body, err := json.Marshal(struct {
+		Name   string `json:"name"`
+		Public bool   `json:"public"`
+	}{playlistName, public})
+	if err != nil {
+		return nil, err
+	}
+	uri := baseAddress + "users/" + userID + "/playlists"
+	resp, err := c.http.Post(uri, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, decodeError(resp.Body)
+	}
+	var p Playlist
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// AddTracksToPlaylist adds one or more tracks to a user's
+// playlist.  Requires the ScopePlaylistModifyPublic scope, or
+// ScopePlaylistModifyPrivate if the playlist is private.
+func (c *Client) AddTracksToPlaylist(userID, playlistID string, ids ...ID) error {
+	uris := make([]string, len(ids))
+	for i, id := range ids {
+		uris[i] = "spotify:track:" + string(id)
+	}
+	body, err := json.Marshal(struct {
+		URIs []string `json:"uris"`
+	}{uris})
+	if err != nil {
+		return err
+	}
+	uri := baseAddress + "users/" + userID + "/playlists/" + playlistID + "/tracks"
+	req, err := http.NewRequest("POST", uri, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return decodeError(resp.Body)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2014, 2015 Zac Bergquist
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotify
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// capturingTransport records the last request it served, then
+// responds with a fixed status and body.
+type capturingTransport struct {
+	code int
+	body string
+	req  *http.Request
+	seen []byte
+}
+
+func (c *capturingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.req = req
+	if req.Body != nil {
+		c.seen, _ = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+	}
+	return &http.Response{
+		StatusCode: c.code,
+		Body:       ioutil.NopCloser(strings.NewReader(c.body)),
+		Header:     make(http.Header),
+	}, nil
+}
+
+func TestAddTracksToLibraryQuery(t *testing.T) {
+	ct := &capturingTransport{code: http.StatusOK, body: ""}
+	client := &Client{http: &http.Client{Transport: ct}}
+
+	if err := client.AddTracksToLibrary(ID("a"), ID("b")); err != nil {
+		t.Fatal(err)
+	}
+	if ct.req.Method != "PUT" {
+		t.Errorf("method = %q, want PUT", ct.req.Method)
+	}
+	if got, want := ct.req.URL.Query().Get("ids"), "a,b"; got != want {
+		t.Errorf("ids query = %q, want %q", got, want)
+	}
+}
+
+func TestCreatePlaylistForUserBody(t *testing.T) {
+	ct := &capturingTransport{code: http.StatusCreated, body: `{"name":"Favorites"}`}
+	ct.body = `{"name":"Favorites"}`
+	client := &Client{http: &http.Client{Transport: ct}}
+
+	p, err := client.CreatePlaylistForUser("user1", "Favorites", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "Favorites" {
+		t.Errorf("playlist name = %q, want %q", p.Name, "Favorites")
+	}
+	want := `{"name":"Favorites","public":true}`
+	if string(ct.seen) != want {
+		t.Errorf("request body = %q, want %q", ct.seen, want)
+	}
+	if ct.req.URL.Path != "/v1/users/user1/playlists" {
+		t.Errorf("request path = %q, want %q", ct.req.URL.Path, "/v1/users/user1/playlists")
+	}
+}
+
+func TestAddTracksToPlaylistBody(t *testing.T) {
+	ct := &capturingTransport{code: http.StatusCreated}
+	client := &Client{http: &http.Client{Transport: ct}}
+
+	if err := client.AddTracksToPlaylist("user1", "playlist1", ID("abc"), ID("def")); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"uris":["spotify:track:abc","spotify:track:def"]}`
+	if string(ct.seen) != want {
+		t.Errorf("request body = %q, want %q", ct.seen, want)
+	}
+	if ct.req.Method != "POST" {
+		t.Errorf("method = %q, want POST", ct.req.Method)
+	}
+}